@@ -0,0 +1,52 @@
+package hexutil
+
+const hextable = "0123456789abcdef"
+
+// nibbleTable maps an ASCII byte to its nibble value, or 0xff if the byte
+// is not a valid hex digit. DecodeFixed ORs the two looked-up nibbles and
+// checks the high bit once, instead of branching on each nibble in turn.
+var nibbleTable = func() [256]byte {
+	var t [256]byte
+	for i := range t {
+		t[i] = 0xff
+	}
+	for i := byte(0); i <= 9; i++ {
+		t['0'+i] = i
+	}
+	for i := byte(0); i <= 5; i++ {
+		t['a'+i] = 10 + i
+		t['A'+i] = 10 + i
+	}
+	return t
+}()
+
+// EncodeToFixed hex-encodes src into dst without a 0x prefix, writing
+// directly into the caller's buffer. dst must be at least len(src)*2
+// bytes long. It is meant for hot paths that already know the size of
+// the value being encoded (addresses, hashes) and want to avoid the
+// allocation Encode performs on every call.
+func EncodeToFixed(dst []byte, src []byte) {
+	for i := 0; i < len(src); i++ {
+		dst[i*2] = hextable[src[i]>>4]
+		dst[i*2+1] = hextable[src[i]&0x0f]
+	}
+}
+
+// DecodeFixed hex-decodes src (without a 0x prefix) into dst. len(src)
+// must be exactly 2*len(dst); any other length is reported as
+// ErrOddLength. A src byte that isn't a valid hex digit is reported as
+// ErrSyntax.
+func DecodeFixed(dst, src []byte) error {
+	if len(src) != len(dst)*2 {
+		return ErrOddLength
+	}
+	for i := 0; i < len(dst); i++ {
+		hi := nibbleTable[src[i*2]]
+		lo := nibbleTable[src[i*2+1]]
+		if hi|lo == 0xff {
+			return ErrSyntax
+		}
+		dst[i] = hi<<4 | lo
+	}
+	return nil
+}
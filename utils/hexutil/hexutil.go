@@ -84,7 +84,7 @@ func Encode(b []byte) string{
 func DecodeUint64(input string) (uint64, error){
 	raw, err := checkNumber(input)
 	if err != nil{
-		return 0, nil
+		return 0, err
 	}
 	dec, err:= strconv.ParseUint(raw, 16, 64)
 	if err != nil{
@@ -93,23 +93,56 @@ func DecodeUint64(input string) (uint64, error){
 	return dec, err 
 }
 
-// MustDecodeUint64 decodes a hex string with 0x prefix as a quantity 
-// It panics for invalid input 
+// MustDecodeUint64 decodes a hex string with 0x prefix as a quantity
+// It panics for invalid input
 func MustDecodeUint64(input string) uint64{
-	dec, err := DecodeUint64(input) 
+	dec, err := DecodeUint64(input)
 	if err != nil{
-		panic(err) 
+		panic(err)
 	}
-	return dec 
+	return dec
 }
 
-// EncodeUint64 encodes i as a hex string with 0x prefix 
+// EncodeUint64 encodes i as a hex string with 0x prefix
 func EncodeUint64(i uint64) string{
 	enc := make([]byte, 2, 10)
-	copy(enc, "0x") 
+	copy(enc, "0x")
 	return string(strconv.AppendUint(enc, i, 16))
 }
 
+// DecodeUint decodes a hex string with 0x prefix as a quantity sized to
+// the host's native uint. On 32-bit platforms, values above 32 bits are
+// rejected with ErrUintRange instead of silently truncating.
+func DecodeUint(input string) (uint, error){
+	raw, err := checkNumber(input)
+	if err != nil{
+		return 0, err
+	}
+	dec, err := strconv.ParseUint(raw, 16, uintBits)
+	if err != nil{
+		err = mapError(err)
+		if err == ErrUint64Range{
+			err = ErrUintRange
+		}
+	}
+	return uint(dec), err
+}
+
+// MustDecodeUint decodes a hex string with 0x prefix as a quantity
+// It panics for invalid input
+func MustDecodeUint(input string) uint{
+	dec, err := DecodeUint(input)
+	if err != nil{
+		panic(err)
+	}
+	return dec
+}
+
+// EncodeUint encodes i as a hex string with 0x prefix
+func EncodeUint(i uint) string{
+	return EncodeUint64(uint64(i))
+}
+
 
 var bigWordNibbles int
 
@@ -0,0 +1,118 @@
+package hexutil
+
+import (
+	"testing"
+)
+
+func FuzzDecode(f *testing.F) {
+	seeds := []string{
+		"0x",
+		"0x0",
+		"0x00",
+		"0xdeadbeef",
+		"0xDEADBEEF",
+		"0xdEaDbEeF",
+		"deadbeef",
+		"0X00",
+		"0x0g",
+		"0",
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		b, err := Decode(input)
+		if err != nil {
+			return
+		}
+		// Encode always lower-cases and uses "0x", so the round trip only
+		// holds for inputs already in that canonical form.
+		if !isCanonicalHex(input) {
+			return
+		}
+		if got := Encode(b); got != input {
+			t.Fatalf("round trip mismatch: Encode(Decode(%q)) = %q", input, got)
+		}
+	})
+}
+
+func FuzzDecodeUint64(f *testing.F) {
+	seeds := []string{
+		"0x0",
+		"0x2",
+		"0x01",
+		"0xffffffffffffffff",
+		"0x10000000000000000",
+		"0x",
+		"",
+		"0xXX",
+		"-0x1",
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		n, err := DecodeUint64(input)
+		if err != nil {
+			return
+		}
+		if !isCanonicalHex(input) {
+			return
+		}
+		if got := EncodeUint64(n); got != input {
+			t.Fatalf("round trip mismatch: EncodeUint64(DecodeUint64(%q)) = %q", input, got)
+		}
+	})
+}
+
+func FuzzDecodeBig(f *testing.F) {
+	seeds := []string{
+		"0x0",
+		"0x2",
+		"0x01",
+		"0x" + repeatHex("f", 64),
+		"0x" + repeatHex("f", 65),
+		"0x",
+		"",
+		"0xXX",
+		"-0x1",
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		n, err := DecodeBig(input)
+		if err != nil {
+			return
+		}
+		if !isCanonicalHex(input) {
+			return
+		}
+		if got := EncodeBig(n); got != input {
+			t.Fatalf("round trip mismatch: EncodeBig(DecodeBig(%q)) = %q", input, got)
+		}
+	})
+}
+
+func repeatHex(s string, n int) string {
+	b := make([]byte, 0, n)
+	for i := 0; i < n; i++ {
+		b = append(b, s[0])
+	}
+	return string(b)
+}
+
+// isCanonicalHex reports whether s is a "0x"-prefixed, all-lowercase hex
+// string, i.e. a string Encode could have produced.
+func isCanonicalHex(s string) bool {
+	if len(s) < 2 || s[0] != '0' || s[1] != 'x' {
+		return false
+	}
+	for i := 2; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package hexutil
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func BenchmarkEncode20(b *testing.B) {
+	src := make([]byte, 20)
+	rand.Read(src)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Encode(src)
+	}
+}
+
+func BenchmarkEncodeToFixed20(b *testing.B) {
+	src := make([]byte, 20)
+	rand.Read(src)
+	dst := make([]byte, 40)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EncodeToFixed(dst, src)
+	}
+}
+
+func BenchmarkEncode32(b *testing.B) {
+	src := make([]byte, 32)
+	rand.Read(src)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Encode(src)
+	}
+}
+
+func BenchmarkEncodeToFixed32(b *testing.B) {
+	src := make([]byte, 32)
+	rand.Read(src)
+	dst := make([]byte, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EncodeToFixed(dst, src)
+	}
+}
+
+func BenchmarkDecode32(b *testing.B) {
+	src := make([]byte, 32)
+	rand.Read(src)
+	enc := Encode(src)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Decode(enc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeFixed32(b *testing.B) {
+	src := make([]byte, 32)
+	rand.Read(src)
+	enc := []byte(Encode(src))[2:]
+	dst := make([]byte, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := DecodeFixed(dst, enc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeToFixed(t *testing.T) {
+	src := []byte{0xde, 0xad, 0xbe, 0xef}
+	dst := make([]byte, 8)
+	EncodeToFixed(dst, src)
+	if string(dst) != "deadbeef" {
+		t.Fatalf("got %q, want %q", dst, "deadbeef")
+	}
+}
+
+func TestDecodeFixed(t *testing.T) {
+	dst := make([]byte, 4)
+	if err := DecodeFixed(dst, []byte("deadbeef")); err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{0xde, 0xad, 0xbe, 0xef}
+	for i := range want {
+		if dst[i] != want[i] {
+			t.Fatalf("got %x, want %x", dst, want)
+		}
+	}
+}
+
+func TestDecodeFixedErrors(t *testing.T) {
+	dst := make([]byte, 4)
+	if err := DecodeFixed(dst, []byte("deadbe")); err != ErrOddLength {
+		t.Fatalf("wrong error for short input: %v", err)
+	}
+	if err := DecodeFixed(dst, []byte("deadbeZZ")); err != ErrSyntax {
+		t.Fatalf("wrong error for invalid hex: %v", err)
+	}
+}
+
+func TestDecodeUint(t *testing.T) {
+	tests := []struct {
+		input string
+		want  uint
+	}{
+		{"0x0", 0},
+		{"0x2", 2},
+		{"0x2F2423", 0x2F2423},
+	}
+	for _, test := range tests {
+		dec, err := DecodeUint(test.input)
+		if err != nil {
+			t.Errorf("%q: %v", test.input, err)
+			continue
+		}
+		if dec != test.want {
+			t.Errorf("%q: got %d, want %d", test.input, dec, test.want)
+		}
+	}
+}
+
+func TestEncodeUintRoundTrip(t *testing.T) {
+	for _, v := range []uint{0, 1, 0xff, 0xdeadbeef} {
+		if got, err := DecodeUint(EncodeUint(v)); err != nil || got != v {
+			t.Errorf("round trip for %d failed: got %d, err %v", v, got, err)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package hexutil
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestBytesMarshalUnmarshal(t *testing.T) {
+	tests := []struct {
+		input []byte
+		want  string
+	}{
+		{nil, `"0x"`},
+		{[]byte{}, `"0x"`},
+		{[]byte{0x01, 0x02}, `"0x0102"`},
+	}
+	for _, test := range tests {
+		enc, err := json.Marshal(Bytes(test.input))
+		if err != nil {
+			t.Errorf("Marshal(%x) error: %v", test.input, err)
+			continue
+		}
+		if string(enc) != test.want {
+			t.Errorf("Marshal(%x) = %s, want %s", test.input, enc, test.want)
+		}
+
+		var dec Bytes
+		if err := json.Unmarshal(enc, &dec); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", enc, err)
+			continue
+		}
+		if string(dec) != string(test.input) {
+			t.Errorf("Unmarshal(%s) = %x, want %x", enc, dec, test.input)
+		}
+	}
+}
+
+func TestBytesUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{`"0xge"`, ErrSyntax},
+		{`"0x0"`, ErrOddLength},
+		{`"abc"`, ErrMissingPrefix},
+	}
+	for _, test := range tests {
+		var dec Bytes
+		err := json.Unmarshal([]byte(test.input), &dec)
+		if err == nil {
+			t.Errorf("Unmarshal(%s) expected error, got nil", test.input)
+			continue
+		}
+		// UnmarshalJSON wraps hexutil errors in a *json.UnmarshalTypeError
+		// whose Value carries the original error message.
+		ute, ok := err.(*json.UnmarshalTypeError)
+		if !ok || ute.Value != test.want.Error() {
+			t.Errorf("Unmarshal(%s) error = %v, want %v", test.input, err, test.want)
+		}
+	}
+}
+
+func TestBigMarshalUnmarshal(t *testing.T) {
+	tests := []struct {
+		input *big.Int
+		want  string
+	}{
+		{big.NewInt(0), `"0x0"`},
+		{big.NewInt(1), `"0x1"`},
+		{big.NewInt(0xff1123), `"0xff1123"`},
+	}
+	for _, test := range tests {
+		enc, err := json.Marshal((*Big)(test.input))
+		if err != nil {
+			t.Errorf("Marshal(%v) error: %v", test.input, err)
+			continue
+		}
+		if string(enc) != test.want {
+			t.Errorf("Marshal(%v) = %s, want %s", test.input, enc, test.want)
+		}
+
+		var dec Big
+		if err := json.Unmarshal(enc, &dec); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", enc, err)
+			continue
+		}
+		if dec.ToInt().Cmp(test.input) != 0 {
+			t.Errorf("Unmarshal(%s) = %v, want %v", enc, dec.ToInt(), test.input)
+		}
+	}
+}
+
+func TestUint64MarshalUnmarshal(t *testing.T) {
+	tests := []struct {
+		input uint64
+		want  string
+	}{
+		{0, `"0x0"`},
+		{1, `"0x1"`},
+		{0xffffffffffffffff, `"0xffffffffffffffff"`},
+	}
+	for _, test := range tests {
+		enc, err := json.Marshal(Uint64(test.input))
+		if err != nil {
+			t.Errorf("Marshal(%d) error: %v", test.input, err)
+			continue
+		}
+		if string(enc) != test.want {
+			t.Errorf("Marshal(%d) = %s, want %s", test.input, enc, test.want)
+		}
+
+		var dec Uint64
+		if err := json.Unmarshal(enc, &dec); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", enc, err)
+			continue
+		}
+		if uint64(dec) != test.input {
+			t.Errorf("Unmarshal(%s) = %d, want %d", enc, dec, test.input)
+		}
+	}
+}
+
+func TestUintMarshalUnmarshal(t *testing.T) {
+	tests := []uint{0, 1, 0xabcdef}
+	for _, want := range tests {
+		enc, err := json.Marshal(Uint(want))
+		if err != nil {
+			t.Errorf("Marshal(%d) error: %v", want, err)
+			continue
+		}
+		var dec Uint
+		if err := json.Unmarshal(enc, &dec); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", enc, err)
+			continue
+		}
+		if uint(dec) != want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", enc, dec, want)
+		}
+	}
+}
+
+func TestUnmarshalFixedJSON(t *testing.T) {
+	var out [4]byte
+	if err := UnmarshalFixedJSON(bytesT, []byte(`"0xdeadbeef"`), out[:]); err != nil {
+		t.Fatal(err)
+	}
+	want := [4]byte{0xde, 0xad, 0xbe, 0xef}
+	if out != want {
+		t.Fatalf("got %x, want %x", out, want)
+	}
+}
+
+func TestUnmarshalFixedTextErrors(t *testing.T) {
+	var out [4]byte
+	if err := UnmarshalFixedText("Hash4", []byte("0xdead"), out[:]); err == nil {
+		t.Fatal("expected error for short input, got nil")
+	}
+	if err := UnmarshalFixedText("Hash4", []byte("0xdeadbeZZ"), out[:]); err != ErrSyntax {
+		t.Fatalf("got %v, want ErrSyntax", err)
+	}
+}
+
+func TestUnmarshalFixedJSONErrors(t *testing.T) {
+	var out [4]byte
+	if err := UnmarshalFixedJSON(bytesT, []byte(`"0xdead"`), out[:]); err == nil {
+		t.Fatal("expected error for short input, got nil")
+	}
+	if err := UnmarshalFixedJSON(bytesT, []byte(`"0xdeadbeZZ"`), out[:]); err == nil {
+		t.Fatal("expected error for invalid hex, got nil")
+	}
+	if err := UnmarshalFixedJSON(bytesT, []byte(`123`), out[:]); err == nil {
+		t.Fatal("expected error for non-string input, got nil")
+	}
+}
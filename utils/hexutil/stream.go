@@ -0,0 +1,170 @@
+package hexutil
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// SyntaxError wraps a decoding error with the byte offset in the input
+// stream at which it occurred.
+type SyntaxError struct {
+	Offset int64
+	Err    error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%v at offset %d", e.Err, e.Offset)
+}
+
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+// encoder streams bytes written to it out as 0x-prefixed hex on the
+// underlying writer, writing the "0x" prefix exactly once, on first
+// Write. Each Write only ever holds a fixed-size chunk in memory, no
+// matter how much data is written overall.
+type encoder struct {
+	w       io.Writer
+	buf     [4096]byte
+	started bool
+}
+
+// NewEncoder returns an io.WriteCloser that hex-encodes everything
+// written to it and forwards it to w, prefixed with "0x". Close must be
+// called to flush the 0x prefix for an encoder that never received any
+// bytes.
+func NewEncoder(w io.Writer) io.WriteCloser {
+	return &encoder{w: w}
+}
+
+func (e *encoder) Write(p []byte) (int, error) {
+	if err := e.writePrefix(); err != nil {
+		return 0, err
+	}
+	total := 0
+	for len(p) > 0 {
+		n := len(p)
+		if n > len(e.buf)/2 {
+			n = len(e.buf) / 2
+		}
+		hex.Encode(e.buf[:n*2], p[:n])
+		if _, err := e.w.Write(e.buf[:n*2]); err != nil {
+			return total, err
+		}
+		total += n
+		p = p[n:]
+	}
+	return total, nil
+}
+
+func (e *encoder) writePrefix() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	_, err := e.w.Write([]byte("0x"))
+	return err
+}
+
+func (e *encoder) Close() error {
+	return e.writePrefix()
+}
+
+// decoder streams 0x-prefixed hex read from the underlying reader back
+// out as raw bytes, consuming the "0x" prefix once, on the first Read.
+type decoder struct {
+	r      io.Reader
+	off    int64
+	prefix bool
+	odd    byte
+	hasOdd bool
+}
+
+// NewDecoder returns an io.Reader that reads 0x-prefixed hex from r and
+// yields the decoded bytes. The prefix is validated and consumed on the
+// first Read call. Errors are reported as *SyntaxError so callers can
+// recover the byte offset at which decoding failed.
+func NewDecoder(r io.Reader) io.Reader {
+	return &decoder{r: r}
+}
+
+func (d *decoder) Read(p []byte) (int, error) {
+	if err := d.readPrefix(); err != nil {
+		return 0, err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	// Each output byte consumes two input hex digits; if an odd digit was
+	// left over from the previous call, consume it first.
+	src := make([]byte, 0, len(p)*2)
+	if d.hasOdd {
+		src = append(src, d.odd)
+		d.hasOdd = false
+	}
+	need := len(p)*2 - len(src)
+	buf := make([]byte, need)
+	n, err := io.ReadFull(d.r, buf)
+	exhausted := err == io.EOF || err == io.ErrUnexpectedEOF
+	src = append(src, buf[:n]...)
+	if len(src)%2 != 0 {
+		d.odd = src[len(src)-1]
+		d.hasOdd = true
+		src = src[:len(src)-1]
+	}
+
+	decoded := len(src) / 2
+	if decoded > 0 {
+		if _, derr := hexDecodeAt(p[:decoded], src, d.off); derr != nil {
+			return 0, derr
+		}
+		d.off += int64(len(src))
+	}
+
+	if !exhausted {
+		return decoded, nil
+	}
+	// The underlying reader is exhausted. A dangling odd nibble at this
+	// point can never be paired, so it's an odd-length hex string rather
+	// than a clean end-of-stream.
+	if d.hasOdd {
+		return decoded, &SyntaxError{Offset: d.off, Err: ErrOddLength}
+	}
+	return decoded, io.EOF
+}
+
+func (d *decoder) readPrefix() error {
+	if d.prefix {
+		return nil
+	}
+	d.prefix = true
+	p := make([]byte, 2)
+	n, err := io.ReadFull(d.r, p)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return &SyntaxError{Offset: int64(n), Err: ErrMissingPrefix}
+	}
+	if err != nil {
+		return err
+	}
+	if !has0xPrefix(string(p)) {
+		return &SyntaxError{Offset: 0, Err: ErrMissingPrefix}
+	}
+	d.off = 2
+	return nil
+}
+
+// hexDecodeAt decodes src into dst, reporting any bad nibble as a
+// *SyntaxError carrying its absolute offset in the stream.
+func hexDecodeAt(dst, src []byte, base int64) (int, error) {
+	for i, c := range src {
+		if nibbleTable[c] == 0xff {
+			return 0, &SyntaxError{Offset: base + int64(i), Err: ErrSyntax}
+		}
+	}
+	n, err := hex.Decode(dst, src)
+	if err != nil {
+		return n, &SyntaxError{Offset: base + int64(len(src)), Err: ErrOddLength}
+	}
+	return n, nil
+}
@@ -0,0 +1,106 @@
+package hexutil
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestEncoderDecoderRoundTrip(t *testing.T) {
+	payload := make([]byte, 10000)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	if _, err := enc.Write(payload[:100]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := enc.Write(payload[100:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want := Encode(payload); buf.String() != want {
+		t.Fatalf("encoder output mismatch: got %d bytes, want %d bytes", buf.Len(), len(want))
+	}
+
+	dec := NewDecoder(bytes.NewReader(buf.Bytes()))
+	got, err := ioutil.ReadAll(dec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("decoded payload mismatch")
+	}
+}
+
+func TestEncoderEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "0x" {
+		t.Fatalf("got %q, want %q", buf.String(), "0x")
+	}
+}
+
+func TestDecoderMissingPrefix(t *testing.T) {
+	dec := NewDecoder(bytes.NewReader([]byte("deadbeef")))
+	_, err := dec.Read(make([]byte, 4))
+	se, ok := err.(*SyntaxError)
+	if !ok || se.Err != ErrMissingPrefix {
+		t.Fatalf("got %v, want SyntaxError wrapping ErrMissingPrefix", err)
+	}
+}
+
+func TestDecoderSyntaxError(t *testing.T) {
+	dec := NewDecoder(bytes.NewReader([]byte("0xdeadbeZZ")))
+	buf := make([]byte, 8)
+	var n int
+	var err error
+	for {
+		var nn int
+		nn, err = dec.Read(buf[n:])
+		n += nn
+		if err != nil {
+			break
+		}
+	}
+	if err == io.EOF {
+		t.Fatal("expected syntax error, got EOF")
+	}
+	se, ok := err.(*SyntaxError)
+	if !ok || se.Err != ErrSyntax {
+		t.Fatalf("got %v, want SyntaxError wrapping ErrSyntax", err)
+	}
+}
+
+func TestDecoderOddLengthAtEOF(t *testing.T) {
+	dec := NewDecoder(bytes.NewReader([]byte("0xabc")))
+	buf := make([]byte, 4)
+	var n int
+	var err error
+	for {
+		var nn int
+		nn, err = dec.Read(buf[n:])
+		n += nn
+		if err != nil {
+			break
+		}
+	}
+	if err == io.EOF {
+		t.Fatal("expected ErrOddLength, got plain EOF")
+	}
+	se, ok := err.(*SyntaxError)
+	if !ok || se.Err != ErrOddLength {
+		t.Fatalf("got %v, want SyntaxError wrapping ErrOddLength", err)
+	}
+	if n != 1 {
+		t.Fatalf("got %d decoded bytes, want 1 (the 'ab' pair)", n)
+	}
+}